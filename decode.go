@@ -0,0 +1,243 @@
+package dotenv
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+/*
+DecodeError is returned by Decode/Unmarshal when one or more struct
+fields could not be populated. It aggregates every field failure
+encountered during a single Decode call, rather than stopping at the
+first one, so that a caller can see everything wrong with its
+configuration at once.
+*/
+type DecodeError struct {
+	Errs []error
+}
+
+func (d *DecodeError) Error() string {
+	msgs := make([]string, len(d.Errs))
+	for i, err := range d.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("dotenv: decode: %s", strings.Join(msgs, "; "))
+}
+
+/*
+Decode populates v, which must be a non-nil pointer to a struct, using
+the key-value pairs held in e. Fields are mapped via an "env" struct
+tag:
+
+	Host string `env:"DB_HOST,required"`
+	Port int     `env:"DB_PORT" default:"5432"`
+
+The tag's first comma-separated part is the key to look up in e; a
+trailing "required" option causes Decode to report an error for that
+field if the key is absent. A "default" tag supplies a fallback value
+used when the key is not set.
+
+Supported field types are string, bool, the sized int/uint/float
+kinds, time.Duration, time.Time (parsed as RFC3339), slices (split on
+the separator given by an "envSeparator" tag, which defaults to ","),
+map[string]T (entries separated the same way, each as "key=value"),
+and nested structs, which are walked recursively - optionally under a
+key prefix given by an "envPrefix" tag. Fields that implement
+encoding.TextUnmarshaler or json.Unmarshaler are decoded via those
+interfaces in preference to any of the above.
+
+Every field that fails to decode is collected; if any did, Decode
+returns a *DecodeError describing all of them.
+*/
+func (e *Env) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dotenv: Decode requires a non-nil pointer to a struct, got %T", v)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if errs := decodeStruct(e.vars, rv.Elem(), ""); len(errs) > 0 {
+		return &DecodeError{Errs: errs}
+	}
+	return nil
+}
+
+// Unmarshal is a package-level convenience wrapper for e.Decode(v),
+// mirroring the json.Unmarshal/yaml.Unmarshal calling convention.
+func Unmarshal(e *Env, v interface{}) error {
+	return e.Decode(v)
+}
+
+func decodeStruct(vars map[string]string, rv reflect.Value, prefix string) []error {
+	rt := rv.Type()
+	var errs []error
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" { // unexported field
+			continue
+		}
+		fv := rv.Field(i)
+
+		tag, ok := field.Tag.Lookup("env")
+
+		// A struct-kind field without its own "env" tag is a nested
+		// group to recurse into. One that does carry an "env" tag is
+		// a leaf to be decoded directly - e.g. via TextUnmarshaler or
+		// json.Unmarshaler in setFieldValue - and must not be treated
+		// as nested, or those interfaces would never get a chance to
+		// run.
+		if fv.Kind() == reflect.Struct && field.Type != timeType && !ok {
+			nestedPrefix := prefix
+			if p, ok := field.Tag.Lookup("envPrefix"); ok {
+				nestedPrefix = prefix + p
+			}
+			errs = append(errs, decodeStruct(vars, fv, nestedPrefix)...)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+		tagParts := strings.Split(tag, ",")
+		name := prefix + tagParts[0]
+		required := false
+		for _, opt := range tagParts[1:] {
+			if opt == "required" {
+				required = true
+			}
+		}
+
+		raw, present := vars[name]
+		if !present {
+			if def, ok := field.Tag.Lookup("default"); ok {
+				raw, present = def, true
+			}
+		}
+		if !present {
+			if required {
+				errs = append(errs, fmt.Errorf("%s: required but not set", name))
+			}
+			continue
+		}
+
+		sep := ","
+		if s, ok := field.Tag.Lookup("envSeparator"); ok {
+			sep = s
+		}
+
+		if err := setFieldValue(fv, raw, sep); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return errs
+}
+
+func setFieldValue(fv reflect.Value, raw, sep string) error {
+	if fv.CanAddr() {
+		addr := fv.Addr()
+		switch {
+		case addr.Type().Implements(textUnmarshalerType):
+			return addr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+		case addr.Type().Implements(jsonUnmarshalerType):
+			return addr.Interface().(json.Unmarshaler).UnmarshalJSON([]byte(raw))
+		}
+	}
+
+	switch fv.Type() {
+	case durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(d))
+		return nil
+	case timeType:
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	case reflect.Slice:
+		parts := splitNonEmpty(raw, sep)
+		slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setFieldValue(slice.Index(i), p, sep); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		fv.Set(slice)
+	case reflect.Map:
+		if fv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type %s", fv.Type().Key())
+		}
+		m := reflect.MakeMap(fv.Type())
+		for _, pair := range splitNonEmpty(raw, sep) {
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				return fmt.Errorf("invalid map entry %q", pair)
+			}
+			val := reflect.New(fv.Type().Elem()).Elem()
+			if err := setFieldValue(val, kv[1], sep); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(kv[0]), val)
+		}
+		fv.Set(m)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+func splitNonEmpty(s, sep string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, sep)
+}
@@ -0,0 +1,149 @@
+package dotenv_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/navaz-alani/dotenv"
+)
+
+type dbConfig struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port int    `env:"PORT,required"`
+}
+
+// level implements encoding.TextUnmarshaler to exercise the decoder's
+// interface-based decoding path for struct-kind fields.
+type level struct {
+	name string
+}
+
+func (l *level) UnmarshalText(text []byte) error {
+	l.name = strings.ToUpper(string(text))
+	return nil
+}
+
+// point implements json.Unmarshaler to exercise the decoder's other
+// interface-based decoding path for struct-kind fields.
+type point struct {
+	X, Y int
+}
+
+func (p *point) UnmarshalJSON(data []byte) error {
+	var raw struct{ X, Y int }
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.X, p.Y = raw.X, raw.Y
+	return nil
+}
+
+type loggingConfig struct {
+	Level  level `env:"LOG_LEVEL"`
+	Origin point `env:"LOG_ORIGIN"`
+}
+
+type appConfig struct {
+	Name    string         `env:"APP_NAME,required"`
+	Debug   bool           `env:"APP_DEBUG" default:"false"`
+	Timeout time.Duration  `env:"APP_TIMEOUT" default:"5s"`
+	Started time.Time      `env:"APP_STARTED"`
+	Tags    []string       `env:"APP_TAGS" envSeparator:"|"`
+	Limits  map[string]int `env:"APP_LIMITS"`
+	DB      dbConfig       `envPrefix:"DB_"`
+}
+
+func newTestEnv(t *testing.T, vars map[string]string) *dotenv.Env {
+	t.Helper()
+	var buf []byte
+	for k, v := range vars {
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v)
+		buf = append(buf, []byte(k+`="`+escaped+`"`+"\n")...)
+	}
+	e := &dotenv.Env{}
+	if err := e.LoadReader(strings.NewReader(string(buf))); err != nil {
+		t.Fatalf("failed to build test env: %v", err)
+	}
+	return e
+}
+
+func TestEnv_Decode(t *testing.T) {
+	e := newTestEnv(t, map[string]string{
+		"APP_NAME":    "svc",
+		"APP_TIMEOUT": "10s",
+		"APP_STARTED": "2024-01-02T15:04:05Z",
+		"APP_TAGS":    "a|b|c",
+		"APP_LIMITS":  "read=1,write=2",
+		"DB_PORT":     "5432",
+	})
+
+	var cfg appConfig
+	if err := e.Decode(&cfg); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if cfg.Name != "svc" {
+		t.Errorf("Name = %q, want svc", cfg.Name)
+	}
+	if cfg.Debug != false {
+		t.Errorf("Debug = %v, want false (from default)", cfg.Debug)
+	}
+	if cfg.Timeout != 10*time.Second {
+		t.Errorf("Timeout = %v, want 10s", cfg.Timeout)
+	}
+	want := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	if !cfg.Started.Equal(want) {
+		t.Errorf("Started = %v, want %v", cfg.Started, want)
+	}
+	if len(cfg.Tags) != 3 || cfg.Tags[0] != "a" || cfg.Tags[2] != "c" {
+		t.Errorf("Tags = %v, want [a b c]", cfg.Tags)
+	}
+	if cfg.Limits["read"] != 1 || cfg.Limits["write"] != 2 {
+		t.Errorf("Limits = %v, want map[read:1 write:2]", cfg.Limits)
+	}
+	if cfg.DB.Host != "localhost" {
+		t.Errorf("DB.Host = %q, want localhost (from default)", cfg.DB.Host)
+	}
+	if cfg.DB.Port != 5432 {
+		t.Errorf("DB.Port = %d, want 5432", cfg.DB.Port)
+	}
+}
+
+func TestEnv_DecodeStructUnmarshalers(t *testing.T) {
+	e := newTestEnv(t, map[string]string{
+		"LOG_LEVEL":  "debug",
+		"LOG_ORIGIN": `{"X":1,"Y":2}`,
+	})
+
+	var cfg loggingConfig
+	if err := e.Decode(&cfg); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if cfg.Level.name != "DEBUG" {
+		t.Errorf("Level.name = %q, want DEBUG (via TextUnmarshaler)", cfg.Level.name)
+	}
+	if cfg.Origin.X != 1 || cfg.Origin.Y != 2 {
+		t.Errorf("Origin = %+v, want {X:1 Y:2} (via json.Unmarshaler)", cfg.Origin)
+	}
+}
+
+func TestEnv_DecodeMissingRequired(t *testing.T) {
+	e := newTestEnv(t, map[string]string{"DB_PORT": "5432"})
+
+	var cfg appConfig
+	err := e.Decode(&cfg)
+	if err == nil {
+		t.Fatal("expected an error for missing required fields")
+	}
+
+	decodeErr, ok := err.(*dotenv.DecodeError)
+	if !ok {
+		t.Fatalf("expected *dotenv.DecodeError, got %T", err)
+	}
+	if len(decodeErr.Errs) != 1 {
+		t.Fatalf("expected 1 aggregated error, got %d: %v", len(decodeErr.Errs), decodeErr.Errs)
+	}
+}
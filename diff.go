@@ -0,0 +1,55 @@
+package dotenv
+
+import "sort"
+
+/*
+Diff compares e against other and reports the keys that differ,
+sorted lexicographically: added holds keys present in other but not
+e, changed holds keys present in both with different values, and
+removed holds keys present in e but not other. This is useful for CI
+checks that flag drift between, e.g., an "example.env" and a ".env".
+*/
+func (e *Env) Diff(other *Env) (added, changed, removed []string) {
+	if e == other {
+		return nil, nil, nil
+	}
+
+	// Snapshot other under its own lock first, rather than holding
+	// both e.mu and other.mu at once, so Diff never has to reason
+	// about lock ordering against a concurrent other.Diff(e).
+	otherVars := other.snapshot()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for k, v := range otherVars {
+		ev, ok := e.vars[k]
+		if !ok {
+			added = append(added, k)
+		} else if ev != v {
+			changed = append(changed, k)
+		}
+	}
+	for k := range e.vars {
+		if _, ok := otherVars[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return added, changed, removed
+}
+
+// snapshot returns a copy of e's variables, taken under e.mu.
+func (e *Env) snapshot() map[string]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	cp := make(map[string]string, len(e.vars))
+	for k, v := range e.vars {
+		cp[k] = v
+	}
+	return cp
+}
@@ -13,10 +13,11 @@ safe for concurrent access by multiple go routines.
 package dotenv
 
 import (
-	"io/ioutil"
-	"regexp"
-	"strings"
+	"io"
+	"os"
 	"sync"
+
+	"github.com/navaz-alani/dotenv/internal/parser"
 )
 
 /*
@@ -25,27 +26,6 @@ another env source file.
 */
 const loadKey = "__GO_LOAD"
 
-/*
-These are regular expressions which match lines in the env
-variable source files.
-*/
-const (
-	// comment is regexp to match a whole-line comment
-	comment = `^[ \t]+#.*?$`
-	/*
-		entry is a regular expression which matches a line with
-		a valid definition of an environment variable.
-		Please note that this regular expression has been written
-		to ignore entries with an empty string ("") as the value.
-	*/
-	entry = `^[ \t]*[^=#]+[ \t]*=[ \t]*"[^"]+"[ \t]*(#?.*)?$`
-	/*
-		kvEntry is used to pick out the key-value pair from a
-		line which could possibly include an inline comment.
-	*/
-	kvEntry = `^[ \t]*[^=#]+[ \t]*=[ \t]*"[^"]+"[ \t]*`
-)
-
 /*
 Env is a type which defines a collection of environment variables.
 */
@@ -60,6 +40,36 @@ type Env struct {
 		environment variables.
 	*/
 	vars map[string]string
+	/*
+		comments holds an optional comment for a subset of keys,
+		attached via SetComment, which Marshal/WriteTo emit above
+		the corresponding entry.
+	*/
+	comments map[string]string
+}
+
+/*
+LoadOptions configures how Load/LoadWithOptions parses a source file,
+in particular whether variable interpolation is performed.
+*/
+type LoadOptions struct {
+	// OverWrite specifies whether keys encountered via a loadKey
+	// ("__GO_LOAD") chain should overwrite keys already present.
+	OverWrite bool
+	// Expand enables POSIX-style "$NAME"/"${NAME}" interpolation in
+	// unquoted and double-quoted values. Single-quoted values are
+	// always left literal. Expansion happens at load time, in file
+	// order, so a variable may reference any key defined earlier in
+	// the same file (or an earlier file in a loadKey chain).
+	Expand bool
+	// ExpandFallbackToOS, when Expand is set, causes names that are
+	// not yet defined in the Env being built to be resolved against
+	// the process environment via os.LookupEnv.
+	ExpandFallbackToOS bool
+	// Lookup, when set, is consulted for a name that Expand cannot
+	// resolve from the Env being built, before falling back to the
+	// process environment. It takes priority over ExpandFallbackToOS.
+	Lookup func(string) (string, bool)
 }
 
 /*
@@ -68,61 +78,131 @@ file. It returns a pointer to an Env type which holds the
 environment variables in that file.
 If all goes well, err will be nil.
 
-Values must be enclosed within quotes, but quotes within the
-value are not permitted (feature to be added). This requirement
-comes as a result of allowing comments in the env source files.
-Also, note that an entry should exist on ONE line only.
+The source file is parsed using the same grammar as LoadReader,
+including unquoted, single- and double-quoted values, optional
+leading "export" keywords and inline comments. When the special
+loadKey ("__GO_LOAD") entry is encountered, Load recurses into the
+referenced file and merges its variables into e using the given
+overWrite flag, before continuing to parse the rest of the source.
 
-Comments can begin a line/start in the middle and continue
-until the end of the line.
+Load does not perform variable expansion; use LoadWithOptions with
+Expand set for "$NAME"/"${NAME}" interpolation.
 */
-func Load(source string, overWrite bool) (e *Env, err error) {
+func Load(source string, overWrite bool) (*Env, error) {
+	return LoadWithOptions(source, LoadOptions{OverWrite: overWrite})
+}
+
+/*
+LoadWithOptions behaves like Load, but accepts a LoadOptions value
+controlling variable expansion. See LoadOptions for details.
+*/
+func LoadWithOptions(source string, opts LoadOptions) (*Env, error) {
+	return loadWithOpener(osOpener, source, opts)
+}
+
+// opener abstracts over the file system Load reads from, so that
+// LoadFS can reuse the same parsing/chaining/expansion logic against
+// an arbitrary fs.FS instead of os.Open.
+type opener func(path string) (io.ReadCloser, error)
+
+func osOpener(path string) (io.ReadCloser, error) {
+	return os.Open(path)
+}
+
+func loadWithOpener(open opener, source string, opts LoadOptions) (*Env, error) {
+	return loadWithOpenerChain(open, source, opts, nil)
+}
+
+// ancestorLookup resolves a variable name against everything defined
+// earlier in an enclosing loadKey ("__GO_LOAD") chain: first the
+// keys an ancestor Env has loaded so far, then its own ancestor, and
+// so on. This lets $VAR/${VAR} expansion see across the whole chain
+// in file order, not just within the file currently being parsed.
+type ancestorLookup func(name string) (string, bool)
+
+func loadWithOpenerChain(open opener, source string, opts LoadOptions, ancestor ancestorLookup) (e *Env, err error) {
 	e = &Env{
 		mu:   sync.Mutex{},
 		vars: make(map[string]string),
 	}
 
-	raw, err := ioutil.ReadFile(source)
+	f, err := open(source)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
 
-	file := string(raw)
-	lines := strings.Split(file, "\n")
+	entries, err := parser.Parse(f)
+	if err != nil {
+		return nil, err
+	}
 
-	validEntry := regexp.MustCompile(entry)
-	commentLine := regexp.MustCompile(comment)
-	keyValEntry := regexp.MustCompile(kvEntry)
+	for _, ent := range entries {
+		if ent.Key == loadKey {
+			subEnv, err := loadWithOpenerChain(open, ent.Value, opts, e.chainedWith(ancestor))
+			if err != nil {
+				return nil, err
+			}
 
-	for _, line := range lines {
-		if commentLine.MatchString(line) ||
-			!validEntry.MatchString(line) ||
-			strings.TrimSpace(line) == "" {
+			e.Merge(subEnv, opts.OverWrite)
 			continue
 		}
 
-		// split line to ignore comment
-		entry := keyValEntry.FindString(line)
-		kvPair := strings.Split(entry, "=")
+		val := ent.Value
+		if opts.Expand && ent.Quote != parser.SingleQuoted {
+			val = e.expand(val, opts, ancestor)
+		}
 
-		key, val := strings.TrimSpace(kvPair[0]), strings.TrimSpace(kvPair[1])
-		// remove quotes
-		val = val[1 : len(val)-1]
+		e.mu.Lock()
+		e.vars[ent.Key] = val
+		e.mu.Unlock()
+	}
 
-		// recursive load if loadKey encountered
-		if key == loadKey {
-			subEnv, err := Load(val, overWrite)
-			if err != nil {
-				return nil, err
-			}
+	return e, nil
+}
 
-			e.Merge(subEnv, overWrite)
+// chainedWith returns an ancestorLookup that checks e's own vars (as
+// loaded so far) before falling back to ancestor.
+func (e *Env) chainedWith(ancestor ancestorLookup) ancestorLookup {
+	return func(name string) (string, bool) {
+		if v, ok := e.vars[name]; ok {
+			return v, true
 		}
+		if ancestor != nil {
+			return ancestor(name)
+		}
+		return "", false
+	}
+}
 
-		e.vars[key] = val
+/*
+LoadReader reads environment variables from r using the same grammar
+as Load, and populates e with the parsed entries, overwriting any
+existing keys. Unlike Load, LoadReader has no access to a file system
+path to resolve against, so a loadKey ("__GO_LOAD") entry encountered
+in r is parsed like any other key rather than triggering a recursive
+load; callers that need chaining should use Load or LoadFS instead.
+
+LoadReader is useful for sourcing an Env from something other than a
+plain file, e.g. bytes embedded with "go:embed" or an in-memory buffer
+assembled in tests.
+*/
+func (e *Env) LoadReader(r io.Reader) error {
+	entries, err := parser.Parse(r)
+	if err != nil {
+		return err
 	}
 
-	return e, nil
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.vars == nil {
+		e.vars = make(map[string]string)
+	}
+	for _, ent := range entries {
+		e.vars[ent.Key] = ent.Value
+	}
+
+	return nil
 }
 
 /*
@@ -132,10 +212,15 @@ that are also in  env should be overWritten with their values
 in env.
 */
 func (e *Env) Merge(env *Env, overWrite bool) {
+	// Snapshot env under its own lock first, rather than holding both
+	// e.mu and env.mu at once, so Merge never has to reason about
+	// lock ordering against a concurrent env.Merge(e, ...).
+	envVars := env.snapshot()
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	for k, v := range env.vars {
+	for k, v := range envVars {
 		if _, ok := e.vars[k]; ok && !overWrite {
 			continue
 		}
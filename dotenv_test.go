@@ -1,6 +1,7 @@
 package dotenv_test
 
 import (
+	"os"
 	"reflect"
 	"testing"
 
@@ -113,3 +114,58 @@ func TestEnv_CheckRequired(t *testing.T) {
 		t.Errorf("Expected undef to be: %v; got %v", reqUndef, undef)
 	}
 }
+
+func TestLoadWithOptionsExpand(t *testing.T) {
+	os.Setenv("DOTENV_TEST_FROM_OS", "os-value")
+	defer os.Unsetenv("DOTENV_TEST_FROM_OS")
+
+	e, err := dotenv.LoadWithOptions("tests/expand.env", dotenv.LoadOptions{
+		Expand:             true,
+		ExpandFallbackToOS: true,
+	})
+	if err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+
+	if url := e.Get("URL"); url != "postgres://localhost:5432/app" {
+		t.Errorf("Expected expanded URL; got %s", url)
+	}
+	if literal := e.Get("LITERAL"); literal != "$HOST stays literal" {
+		t.Errorf("Expected single-quoted value to stay literal; got %s", literal)
+	}
+	if fromOS := e.Get("FROM_OS"); fromOS != "os-value" {
+		t.Errorf("Expected fallback to process environment; got %s", fromOS)
+	}
+}
+
+func TestLoadWithOptionsExpandAcrossLoadChain(t *testing.T) {
+	e, err := dotenv.LoadWithOptions("tests/expand-parent.env", dotenv.LoadOptions{
+		Expand: true,
+	})
+	if err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+
+	if url := e.Get("URL"); url != "http://localhost/app" {
+		t.Errorf("Expected a key defined in the parent file to expand into the __GO_LOAD child; got %s", url)
+	}
+}
+
+func TestLoadWithOptionsExpandCustomLookup(t *testing.T) {
+	e, err := dotenv.LoadWithOptions("tests/expand.env", dotenv.LoadOptions{
+		Expand: true,
+		Lookup: func(name string) (string, bool) {
+			if name == "DOTENV_TEST_FROM_OS" {
+				return "from-lookup", true
+			}
+			return "", false
+		},
+	})
+	if err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+
+	if fromOS := e.Get("FROM_OS"); fromOS != "from-lookup" {
+		t.Errorf("Expected value from custom Lookup; got %s", fromOS)
+	}
+}
@@ -0,0 +1,50 @@
+package dotenv
+
+import (
+	"os"
+	"strings"
+)
+
+/*
+OverrideFromEnviron scans the process environment (os.Environ) for
+variables whose name begins with prefix, strips the prefix and
+injects the remainder into e, overwriting any existing key of the
+same name. Unlike the expansion fallback in LoadOptions, which only
+supplies values for names referenced from a loaded value, this walks the process
+environment itself, so it can introduce keys that were never present
+in any loaded source file - the way deployment tooling typically
+supplies configuration overrides (e.g. APP_DB_HOST becomes DB_HOST).
+*/
+func (e *Env) OverrideFromEnviron(prefix string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.vars == nil {
+		e.vars = make(map[string]string)
+	}
+
+	for _, kv := range os.Environ() {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		e.vars[strings.TrimPrefix(k, prefix)] = v
+	}
+}
+
+/*
+ApplyToOS exports every key-value pair in e to the process
+environment via os.Setenv, optionally prepending prefix to each key
+first. It lets an Env seed the environment that child processes
+inherit.
+*/
+func (e *Env) ApplyToOS(prefix string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for k, v := range e.vars {
+		if err := os.Setenv(prefix+k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package dotenv_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/navaz-alani/dotenv"
+)
+
+func TestEnv_OverrideFromEnviron(t *testing.T) {
+	os.Setenv("DOTENV_TEST_PREFIX_HOST", "fromenv")
+	defer os.Unsetenv("DOTENV_TEST_PREFIX_HOST")
+
+	e, err := dotenv.Load("tests/noComments.env", true)
+	if err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+
+	e.OverrideFromEnviron("DOTENV_TEST_PREFIX_")
+	if host := e.Get("HOST"); host != "fromenv" {
+		t.Errorf("Expected HOST to be injected from the environment; got %q", host)
+	}
+}
+
+func TestEnv_ApplyToOS(t *testing.T) {
+	e, err := dotenv.Load("tests/noComments.env", true)
+	if err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+	defer os.Unsetenv("DOTENV_APPLY_key1")
+
+	if err := e.ApplyToOS("DOTENV_APPLY_"); err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+
+	if got := os.Getenv("DOTENV_APPLY_key1"); got != "test1" {
+		t.Errorf("Expected process env DOTENV_APPLY_key1 to be set; got %q", got)
+	}
+}
@@ -0,0 +1,86 @@
+package dotenv
+
+import (
+	"os"
+	"strings"
+)
+
+// expand substitutes "$NAME" and "${NAME}" references in val with
+// values already loaded into e, falling back to ancestor (the
+// enclosing loadKey chain, if any), then opts.Lookup, then the
+// process environment as configured by opts. Undefined names expand
+// to the empty string.
+func (e *Env) expand(val string, opts LoadOptions, ancestor ancestorLookup) string {
+	var out strings.Builder
+	i, n := 0, len(val)
+
+	for i < n {
+		if val[i] != '$' || i+1 >= n {
+			out.WriteByte(val[i])
+			i++
+			continue
+		}
+
+		if val[i+1] == '{' {
+			end := strings.IndexByte(val[i+2:], '}')
+			if end < 0 {
+				out.WriteByte(val[i])
+				i++
+				continue
+			}
+			name := val[i+2 : i+2+end]
+			out.WriteString(e.resolve(name, opts, ancestor))
+			i = i + 2 + end + 1
+			continue
+		}
+
+		if !isIdentStart(val[i+1]) {
+			out.WriteByte(val[i])
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < n && isIdentPart(val[j]) {
+			j++
+		}
+		out.WriteString(e.resolve(val[i+1:j], opts, ancestor))
+		i = j
+	}
+
+	return out.String()
+}
+
+// resolve looks up name for expansion: first among the keys already
+// loaded into e, then the enclosing loadKey chain (if any), then
+// opts.Lookup, then (if enabled) the process environment. An
+// unresolved name expands to the empty string.
+func (e *Env) resolve(name string, opts LoadOptions, ancestor ancestorLookup) string {
+	if v, ok := e.vars[name]; ok {
+		return v
+	}
+	if ancestor != nil {
+		if v, ok := ancestor(name); ok {
+			return v
+		}
+	}
+	if opts.Lookup != nil {
+		if v, ok := opts.Lookup(name); ok {
+			return v
+		}
+	}
+	if opts.ExpandFallbackToOS {
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,239 @@
+package dotenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+CheckRequired returns the subset of required that are either absent
+from e or set to the empty string, in the order they appear in
+required. An empty result means every key in required is present and
+non-empty.
+*/
+func (e *Env) CheckRequired(required []string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var undef []string
+	for _, key := range required {
+		if v, ok := e.vars[key]; !ok || v == "" {
+			undef = append(undef, key)
+		}
+	}
+	return undef
+}
+
+// GetString retrieves the string value of key, or "" if it is unset.
+func (e *Env) GetString(key string) string {
+	return e.Get(key)
+}
+
+// GetStringDefault retrieves the string value of key, or def if key
+// is unset or empty.
+func (e *Env) GetStringDefault(key, def string) string {
+	if v := e.Get(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// MustGetString retrieves the string value of key, panicking if it
+// is unset or empty.
+func (e *Env) MustGetString(key string) string {
+	v := e.Get(key)
+	if v == "" {
+		panic(fmt.Sprintf("dotenv: required key %q is not set", key))
+	}
+	return v
+}
+
+// GetInt retrieves and parses the value of key as an int.
+func (e *Env) GetInt(key string) (int, error) {
+	v, err := e.requireValue(key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("dotenv: key %q: %w", key, err)
+	}
+	return n, nil
+}
+
+// GetIntDefault is like GetInt, but returns def instead of an error.
+func (e *Env) GetIntDefault(key string, def int) int {
+	n, err := e.GetInt(key)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// MustGetInt is like GetInt, but panics instead of returning an error.
+func (e *Env) MustGetInt(key string) int {
+	n, err := e.GetInt(key)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// GetInt64 retrieves and parses the value of key as an int64.
+func (e *Env) GetInt64(key string) (int64, error) {
+	v, err := e.requireValue(key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("dotenv: key %q: %w", key, err)
+	}
+	return n, nil
+}
+
+// GetInt64Default is like GetInt64, but returns def instead of an error.
+func (e *Env) GetInt64Default(key string, def int64) int64 {
+	n, err := e.GetInt64(key)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// MustGetInt64 is like GetInt64, but panics instead of returning an error.
+func (e *Env) MustGetInt64(key string) int64 {
+	n, err := e.GetInt64(key)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// GetFloat64 retrieves and parses the value of key as a float64.
+func (e *Env) GetFloat64(key string) (float64, error) {
+	v, err := e.requireValue(key)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("dotenv: key %q: %w", key, err)
+	}
+	return f, nil
+}
+
+// GetFloat64Default is like GetFloat64, but returns def instead of an error.
+func (e *Env) GetFloat64Default(key string, def float64) float64 {
+	f, err := e.GetFloat64(key)
+	if err != nil {
+		return def
+	}
+	return f
+}
+
+// MustGetFloat64 is like GetFloat64, but panics instead of returning an error.
+func (e *Env) MustGetFloat64(key string) float64 {
+	f, err := e.GetFloat64(key)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// GetDuration retrieves and parses the value of key as a time.Duration,
+// using the same syntax as time.ParseDuration (e.g. "5s", "1h30m").
+func (e *Env) GetDuration(key string) (time.Duration, error) {
+	v, err := e.requireValue(key)
+	if err != nil {
+		return 0, err
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("dotenv: key %q: %w", key, err)
+	}
+	return d, nil
+}
+
+// GetDurationDefault is like GetDuration, but returns def instead of an error.
+func (e *Env) GetDurationDefault(key string, def time.Duration) time.Duration {
+	d, err := e.GetDuration(key)
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+// MustGetDuration is like GetDuration, but panics instead of returning an error.
+func (e *Env) MustGetDuration(key string) time.Duration {
+	d, err := e.GetDuration(key)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// GetBool retrieves and parses the value of key as a bool, accepting
+// "1"/"0", "true"/"false", "yes"/"no" and "on"/"off", case-insensitive.
+func (e *Env) GetBool(key string) (bool, error) {
+	v, err := e.requireValue(key)
+	if err != nil {
+		return false, err
+	}
+	b, err := parseBoolLoose(v)
+	if err != nil {
+		return false, fmt.Errorf("dotenv: key %q: %w", key, err)
+	}
+	return b, nil
+}
+
+// GetBoolDefault is like GetBool, but returns def instead of an error.
+func (e *Env) GetBoolDefault(key string, def bool) bool {
+	b, err := e.GetBool(key)
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+// MustGetBool is like GetBool, but panics instead of returning an error.
+func (e *Env) MustGetBool(key string) bool {
+	b, err := e.GetBool(key)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSlice splits the value of key on sep, returning nil if key is
+// unset or empty.
+func (e *Env) GetSlice(key, sep string) []string {
+	v := e.Get(key)
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, sep)
+}
+
+// requireValue fetches key, returning an error if it is unset or
+// empty, for use by the typed getters above.
+func (e *Env) requireValue(key string) (string, error) {
+	v := e.Get(key)
+	if v == "" {
+		return "", fmt.Errorf("dotenv: key %q is not set", key)
+	}
+	return v, nil
+}
+
+func parseBoolLoose(v string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "yes", "on":
+		return true, nil
+	case "0", "false", "no", "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean value %q", v)
+	}
+}
@@ -0,0 +1,61 @@
+package dotenv_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/navaz-alani/dotenv"
+)
+
+func newGetterEnv(t *testing.T) *dotenv.Env {
+	t.Helper()
+	e := &dotenv.Env{}
+	src := `INT="42"
+FLOAT="3.14"
+DURATION="1h30m"
+BOOL_YES="yes"
+BOOL_OFF="Off"
+SLICE="a,b,c"
+`
+	if err := e.LoadReader(strings.NewReader(src)); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestEnv_TypedGetters(t *testing.T) {
+	e := newGetterEnv(t)
+
+	if n, err := e.GetInt("INT"); err != nil || n != 42 {
+		t.Errorf("GetInt(INT) = %d, %v; want 42, nil", n, err)
+	}
+	if f, err := e.GetFloat64("FLOAT"); err != nil || f != 3.14 {
+		t.Errorf("GetFloat64(FLOAT) = %v, %v; want 3.14, nil", f, err)
+	}
+	if d, err := e.GetDuration("DURATION"); err != nil || d != 90*time.Minute {
+		t.Errorf("GetDuration(DURATION) = %v, %v; want 1h30m, nil", d, err)
+	}
+	if b, err := e.GetBool("BOOL_YES"); err != nil || !b {
+		t.Errorf("GetBool(BOOL_YES) = %v, %v; want true, nil", b, err)
+	}
+	if b, err := e.GetBool("BOOL_OFF"); err != nil || b {
+		t.Errorf("GetBool(BOOL_OFF) = %v, %v; want false, nil", b, err)
+	}
+	if got := e.GetSlice("SLICE", ","); len(got) != 3 || got[1] != "b" {
+		t.Errorf("GetSlice(SLICE) = %v, want [a b c]", got)
+	}
+	if n := e.GetIntDefault("MISSING", 7); n != 7 {
+		t.Errorf("GetIntDefault(MISSING) = %d, want 7", n)
+	}
+}
+
+func TestEnv_MustGetPanicsOnMissing(t *testing.T) {
+	e := newGetterEnv(t)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected MustGetInt to panic for a missing key")
+		}
+	}()
+	e.MustGetInt("MISSING")
+}
@@ -0,0 +1,254 @@
+/*
+Package parser implements a small tokenizing parser for the dotenv
+file grammar. It is used internally by the dotenv package to turn the
+raw bytes of an env source file into an ordered list of key-value
+Entry values, and is kept separate from the dotenv package so that
+the grammar can be tested in isolation from Env's map/merge semantics.
+
+The grammar supported is the shell-compatible subset seen in most
+ecosystem dotenv parsers:
+
+	FOO=bar                 unquoted value
+	FOO='bar'               single-quoted literal, no escapes
+	FOO="bar\nbaz"          double-quoted value with \n, \t, \" escapes
+	export FOO=bar          optional leading "export" keyword
+	FOO=                    empty value
+	FOO=bar # comment       inline comment (must be preceded by whitespace)
+	FOO=bar#baz             '#' is part of the value, not a comment
+	FOO = "bar"             whitespace is tolerated around '='
+
+Quoted values may also span multiple lines; the value runs until the
+matching closing quote is found, raw newlines included.
+*/
+package parser
+
+import (
+	"fmt"
+	"io"
+)
+
+// Quote identifies how an Entry's value was quoted in the source,
+// which matters to callers that interpolate variables: single-quoted
+// values are literal and must not be expanded.
+type Quote int
+
+const (
+	// Unquoted is used for values without surrounding quotes.
+	Unquoted Quote = iota
+	// SingleQuoted is used for values wrapped in '...'.
+	SingleQuoted
+	// DoubleQuoted is used for values wrapped in "...".
+	DoubleQuoted
+)
+
+// Entry is a single key-value definition parsed from an env source,
+// in the order it was encountered.
+type Entry struct {
+	Key    string
+	Value  string
+	Quote  Quote
+	Export bool
+}
+
+// Parse reads all of r and returns the Entry values it contains, in
+// file order. An error is returned if the input does not conform to
+// the grammar described in the package doc, e.g. a quoted value with
+// no closing quote.
+func Parse(r io.Reader) ([]Entry, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parse(string(raw))
+}
+
+// scanner walks the source string left to right, tracking the current
+// line number for error messages.
+type scanner struct {
+	src  string
+	pos  int
+	line int
+}
+
+func parse(src string) ([]Entry, error) {
+	s := &scanner{src: src, line: 1}
+	var entries []Entry
+
+	for !s.atEnd() {
+		s.skipHSpace()
+		if s.peek() == '\n' {
+			s.advance()
+			continue
+		}
+		if s.atEnd() {
+			break
+		}
+		if s.peek() == '#' {
+			s.skipToEOL()
+			continue
+		}
+
+		startLine := s.line
+		key, err := s.scanIdent()
+		if err != nil {
+			return nil, fmt.Errorf("parser: line %d: %w", startLine, err)
+		}
+
+		export := false
+		if key == "export" {
+			save := s.pos
+			s.skipHSpace()
+			if !s.atEnd() && s.peek() != '=' {
+				export = true
+				key, err = s.scanIdent()
+				if err != nil {
+					return nil, fmt.Errorf("parser: line %d: %w", startLine, err)
+				}
+			} else {
+				s.pos = save
+			}
+		}
+
+		s.skipHSpace()
+		if s.atEnd() || s.peek() != '=' {
+			return nil, fmt.Errorf("parser: line %d: expected '=' after %q", startLine, key)
+		}
+		s.advance() // consume '='
+		s.skipHSpace()
+
+		value, quote, err := s.scanValue()
+		if err != nil {
+			return nil, fmt.Errorf("parser: line %d: %w", startLine, err)
+		}
+
+		entries = append(entries, Entry{Key: key, Value: value, Quote: quote, Export: export})
+
+		// Consume the remainder of the line: an inline comment is only
+		// honoured here because scanValue already stopped at the first
+		// unquoted '#' preceded by whitespace (or at the closing quote).
+		s.skipHSpace()
+		if !s.atEnd() && s.peek() == '#' {
+			s.skipToEOL()
+		}
+		if !s.atEnd() && s.peek() == '\n' {
+			s.advance()
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *scanner) atEnd() bool { return s.pos >= len(s.src) }
+
+func (s *scanner) peek() byte {
+	if s.atEnd() {
+		return 0
+	}
+	return s.src[s.pos]
+}
+
+func (s *scanner) advance() byte {
+	c := s.src[s.pos]
+	s.pos++
+	if c == '\n' {
+		s.line++
+	}
+	return c
+}
+
+func (s *scanner) skipHSpace() {
+	for !s.atEnd() && isHSpace(s.peek()) {
+		s.pos++
+	}
+}
+
+func (s *scanner) skipToEOL() {
+	for !s.atEnd() && s.peek() != '\n' {
+		s.pos++
+	}
+}
+
+// scanIdent reads a bare identifier: a key, or the "export" keyword.
+func (s *scanner) scanIdent() (string, error) {
+	start := s.pos
+	for !s.atEnd() && !isHSpace(s.peek()) && s.peek() != '=' && s.peek() != '\n' && s.peek() != '#' {
+		s.pos++
+	}
+	if s.pos == start {
+		return "", fmt.Errorf("expected a key, found %q", string(s.peek()))
+	}
+	return s.src[start:s.pos], nil
+}
+
+// scanValue dispatches to the quoted or unquoted value scanner
+// depending on the next byte.
+func (s *scanner) scanValue() (string, Quote, error) {
+	switch s.peek() {
+	case '\'':
+		v, err := s.scanQuoted('\'', false)
+		return v, SingleQuoted, err
+	case '"':
+		v, err := s.scanQuoted('"', true)
+		return v, DoubleQuoted, err
+	default:
+		return s.scanUnquoted(), Unquoted, nil
+	}
+}
+
+// scanQuoted reads a value delimited by the given quote byte. When
+// escapes is true, the double-quote escape sequences \n, \t, \" and
+// \\ are decoded; single-quoted values are taken verbatim.
+func (s *scanner) scanQuoted(delim byte, escapes bool) (string, error) {
+	s.advance() // opening quote
+	var out []byte
+	for {
+		if s.atEnd() {
+			return "", fmt.Errorf("unterminated %q-quoted value", string(delim))
+		}
+		c := s.advance()
+		if c == delim {
+			return string(out), nil
+		}
+		if escapes && c == '\\' && !s.atEnd() {
+			switch s.peek() {
+			case 'n':
+				out = append(out, '\n')
+				s.advance()
+			case 't':
+				out = append(out, '\t')
+				s.advance()
+			case '"':
+				out = append(out, '"')
+				s.advance()
+			case '\\':
+				out = append(out, '\\')
+				s.advance()
+			default:
+				out = append(out, c)
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+}
+
+// scanUnquoted reads a value up to end of line, stopping early at a
+// '#' that begins an inline comment. A '#' only starts a comment when
+// preceded by whitespace (or at the very start of the value), so that
+// "foo#bar" is kept intact while "foo #bar" is trimmed to "foo".
+func (s *scanner) scanUnquoted() string {
+	start := s.pos
+	end := s.pos
+	for !s.atEnd() && s.peek() != '\n' {
+		if s.peek() == '#' && (s.pos == start || isHSpace(s.src[s.pos-1])) {
+			break
+		}
+		s.pos++
+		if !isHSpace(s.src[s.pos-1]) {
+			end = s.pos
+		}
+	}
+	return s.src[start:end]
+}
+
+func isHSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\r' }
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []Entry
+	}{
+		{
+			name: "unquoted",
+			src:  "FOO=bar\n",
+			want: []Entry{{Key: "FOO", Value: "bar", Quote: Unquoted}},
+		},
+		{
+			name: "single quoted literal",
+			src:  `FOO='bar\n'` + "\n",
+			want: []Entry{{Key: "FOO", Value: `bar\n`, Quote: SingleQuoted}},
+		},
+		{
+			name: "double quoted escapes",
+			src:  `FOO="bar\nbaz\t\"q\""` + "\n",
+			want: []Entry{{Key: "FOO", Value: "bar\nbaz\t\"q\"", Quote: DoubleQuoted}},
+		},
+		{
+			name: "export keyword",
+			src:  "export FOO=bar\n",
+			want: []Entry{{Key: "FOO", Value: "bar", Quote: Unquoted, Export: true}},
+		},
+		{
+			name: "key literally named export",
+			src:  "export=bar\n",
+			want: []Entry{{Key: "export", Value: "bar", Quote: Unquoted}},
+		},
+		{
+			name: "empty value",
+			src:  "FOO=\n",
+			want: []Entry{{Key: "FOO", Value: "", Quote: Unquoted}},
+		},
+		{
+			name: "whitespace around equals",
+			src:  "FOO = bar\n",
+			want: []Entry{{Key: "FOO", Value: "bar", Quote: Unquoted}},
+		},
+		{
+			name: "inline comment after whitespace is stripped",
+			src:  "FOO=bar # comment\n",
+			want: []Entry{{Key: "FOO", Value: "bar", Quote: Unquoted}},
+		},
+		{
+			name: "hash glued to value is kept",
+			src:  "bar=foo#baz\n",
+			want: []Entry{{Key: "bar", Value: "foo#baz", Quote: Unquoted}},
+		},
+		{
+			name: "whole line comment is ignored",
+			src:  "# a comment\nFOO=bar\n",
+			want: []Entry{{Key: "FOO", Value: "bar", Quote: Unquoted}},
+		},
+		{
+			name: "multi-line double quoted value",
+			src:  "FOO=\"line one\nline two\"\nBAR=baz\n",
+			want: []Entry{
+				{Key: "FOO", Value: "line one\nline two", Quote: DoubleQuoted},
+				{Key: "BAR", Value: "baz", Quote: Unquoted},
+			},
+		},
+		{
+			name: "blank lines between entries",
+			src:  "FOO=bar\n\n\nBAZ=qux\n",
+			want: []Entry{
+				{Key: "FOO", Value: "bar", Quote: Unquoted},
+				{Key: "BAZ", Value: "qux", Quote: Unquoted},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Parse() = %#v, want %#v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("entry %d = %#v, want %#v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseUnterminatedQuote(t *testing.T) {
+	if _, err := Parse(strings.NewReader(`FOO="bar`)); err == nil {
+		t.Fatal("expected an error for an unterminated quoted value")
+	}
+}
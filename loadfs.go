@@ -0,0 +1,69 @@
+package dotenv
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+)
+
+/*
+LoadFS behaves like Load, but reads name from fsys instead of the
+host file system, and resolves any loadKey ("__GO_LOAD") chain
+through fsys as well. This allows sourcing env files from an
+"embed.FS" built with go:embed, or from any other fs.FS, e.g. a
+virtual filesystem assembled in tests.
+*/
+func LoadFS(fsys fs.FS, name string, overwrite bool) (*Env, error) {
+	return loadWithOpener(fsOpener(fsys), name, LoadOptions{OverWrite: overwrite})
+}
+
+func fsOpener(fsys fs.FS) opener {
+	return func(name string) (io.ReadCloser, error) {
+		return fsys.Open(name)
+	}
+}
+
+/*
+LoadProfile composes the layered set of env files conventionally used
+for dev/staging/prod setups, reading each from fsys under dir:
+
+	.env
+	.env.local
+	.env.{profile}
+	.env.{profile}.local
+
+Files are loaded in that order and each successive layer overwrites
+keys set by the previous one, matching the standard "twelve-factor"
+dotenv precedence; a layer that does not exist is skipped rather than
+treated as an error. If profile is empty, only ".env" and
+".env.local" are considered. The overwrite flag is passed through to
+each layer's own loadKey ("__GO_LOAD") chain resolution.
+*/
+func LoadProfile(fsys fs.FS, dir, profile string, overwrite bool) (*Env, error) {
+	names := []string{".env", ".env.local"}
+	if profile != "" {
+		names = append(names, ".env."+profile, ".env."+profile+".local")
+	}
+
+	e := &Env{vars: make(map[string]string)}
+	for _, name := range names {
+		p := name
+		if dir != "" {
+			p = path.Join(dir, name)
+		}
+
+		if _, err := fs.Stat(fsys, p); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		layer, err := LoadFS(fsys, p, overwrite)
+		if err != nil {
+			return nil, err
+		}
+
+		e.Merge(layer, true)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,57 @@
+package dotenv_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/navaz-alani/dotenv"
+)
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sub.env":  {Data: []byte(`key1="fromsub"` + "\n")},
+		"main.env": {Data: []byte("key1=\"main\"\n__GO_LOAD=\"sub.env\"\n")},
+	}
+
+	e, err := dotenv.LoadFS(fsys, "main.env", true)
+	if err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+	if got := e.Get("key1"); got != "fromsub" {
+		t.Errorf("Expected key1 = fromsub; got %s", got)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/.env":            {Data: []byte(`HOST="base"` + "\n" + `PORT="1"` + "\n")},
+		"config/.env.local":      {Data: []byte(`HOST="local"` + "\n")},
+		"config/.env.prod":       {Data: []byte(`HOST="prod"` + "\n" + `PORT="2"` + "\n")},
+		"config/.env.prod.local": {Data: []byte(`PORT="3"` + "\n")},
+	}
+
+	e, err := dotenv.LoadProfile(fsys, "config", "prod", true)
+	if err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+	if got := e.Get("HOST"); got != "prod" {
+		t.Errorf("Expected HOST = prod; got %s", got)
+	}
+	if got := e.Get("PORT"); got != "3" {
+		t.Errorf("Expected PORT = 3; got %s", got)
+	}
+}
+
+func TestLoadProfileSkipsMissingLayers(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/.env": {Data: []byte(`HOST="base"` + "\n")},
+	}
+
+	e, err := dotenv.LoadProfile(fsys, "config", "staging", true)
+	if err != nil {
+		t.Fatal("Expected no error; got ", err)
+	}
+	if got := e.Get("HOST"); got != "base" {
+		t.Errorf("Expected HOST = base; got %s", got)
+	}
+}
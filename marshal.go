@@ -0,0 +1,196 @@
+package dotenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+SetComment attaches a comment to key, which Marshal and WriteTo will
+emit as a whole-line "# comment" immediately above that key's entry
+the next time e is serialized. Passing an empty comment removes any
+comment previously set for key.
+*/
+func (e *Env) SetComment(key, comment string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if comment == "" {
+		delete(e.comments, key)
+		return
+	}
+	if e.comments == nil {
+		e.comments = make(map[string]string)
+	}
+	e.comments[key] = comment
+}
+
+/*
+Marshal serializes e to the canonical dotenv format: keys sorted
+lexicographically, values always double-quoted with '"', '\\' and
+'\n' escaped, and any comment set via SetComment emitted above its
+key.
+*/
+func (e *Env) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := e.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+/*
+WriteTo writes e to w in the same canonical dotenv format as Marshal,
+and returns the number of bytes written.
+*/
+func (e *Env) WriteTo(w io.Writer) (int64, error) {
+	e.mu.Lock()
+	keys := make([]string, 0, len(e.vars))
+	for k := range e.vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		if c, ok := e.comments[k]; ok {
+			fmt.Fprintf(&buf, "# %s\n", c)
+		}
+		fmt.Fprintf(&buf, "%s=%s\n", k, quoteDotenv(e.vars[k]))
+	}
+	e.mu.Unlock()
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func quoteDotenv(v string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range v {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// MarshalJSON implements json.Marshaler, encoding e as a flat JSON
+// object of its key-value pairs.
+func (e *Env) MarshalJSON() ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return json.Marshal(e.vars)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing e's variables
+// with the flat JSON object in data.
+func (e *Env) UnmarshalJSON(data []byte) error {
+	vars := make(map[string]string)
+	if err := json.Unmarshal(data, &vars); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.vars = vars
+	return nil
+}
+
+/*
+MarshalYAML encodes e as a flat YAML mapping of its key-value pairs,
+keys sorted lexicographically. It supports the restricted subset of
+YAML needed to round-trip string scalars - sufficient for config
+pipelines that treat an Env as a two-way tool - rather than the full
+YAML spec.
+*/
+func (e *Env) MarshalYAML() ([]byte, error) {
+	e.mu.Lock()
+	keys := make([]string, 0, len(e.vars))
+	for k := range e.vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "%s: %s\n", k, yamlScalar(e.vars[k]))
+	}
+	e.mu.Unlock()
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalYAML replaces e's variables with the flat YAML mapping
+// decoded from data. See MarshalYAML for the supported subset.
+func (e *Env) UnmarshalYAML(data []byte) error {
+	vars := make(map[string]string)
+
+	for i, rawLine := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(rawLine, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			return fmt.Errorf("dotenv: yaml line %d: expected \"key: value\", got %q", i+1, line)
+		}
+		key := strings.TrimSpace(line[:idx])
+		val := strings.TrimSpace(line[idx+1:])
+		if strings.HasPrefix(val, `"`) {
+			unquoted, err := strconv.Unquote(val)
+			if err != nil {
+				return fmt.Errorf("dotenv: yaml line %d: %w", i+1, err)
+			}
+			val = unquoted
+		}
+		vars[key] = val
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.vars = vars
+	return nil
+}
+
+func yamlScalar(v string) string {
+	if v == "" || needsYAMLQuote(v) {
+		return strconv.Quote(v)
+	}
+	return v
+}
+
+func needsYAMLQuote(v string) bool {
+	if strings.TrimSpace(v) != v {
+		return true
+	}
+	switch strings.ToLower(v) {
+	case "true", "false", "null", "~":
+		return true
+	}
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return true
+	}
+	for _, r := range v {
+		switch r {
+		case ':', '#', '\n', '\'', '"', '{', '}', '[', ']', ',', '&', '*', '!', '|', '>', '%', '@', '`':
+			return true
+		}
+	}
+	return false
+}
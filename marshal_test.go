@@ -0,0 +1,145 @@
+package dotenv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/navaz-alani/dotenv"
+)
+
+func TestEnv_MarshalRoundTrip(t *testing.T) {
+	e := &dotenv.Env{}
+	if err := e.LoadReader(strings.NewReader("b=\"two\\nlines\"\na=\"first\"\n")); err != nil {
+		t.Fatal(err)
+	}
+	e.SetComment("a", "first key")
+
+	out, err := e.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := "# first key\na=\"first\"\nb=\"two\\nlines\"\n"
+	if string(out) != want {
+		t.Errorf("Marshal() = %q, want %q", out, want)
+	}
+
+	roundTripped := &dotenv.Env{}
+	if err := roundTripped.LoadReader(strings.NewReader(string(out))); err != nil {
+		t.Fatalf("failed to reload marshaled output: %v", err)
+	}
+	if got := roundTripped.Get("b"); got != "two\nlines" {
+		t.Errorf("round-tripped b = %q, want %q", got, "two\nlines")
+	}
+}
+
+func TestEnv_JSONRoundTrip(t *testing.T) {
+	e := &dotenv.Env{}
+	if err := e.LoadReader(strings.NewReader(`a="1"` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := e.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+
+	var other dotenv.Env
+	if err := other.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if got := other.Get("a"); got != "1" {
+		t.Errorf("Get(a) = %q, want %q", got, "1")
+	}
+}
+
+func TestEnv_YAMLRoundTrip(t *testing.T) {
+	e := &dotenv.Env{}
+	if err := e.LoadReader(strings.NewReader("a=\"1\"\nb=\"has: colon\"\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := e.MarshalYAML()
+	if err != nil {
+		t.Fatalf("MarshalYAML() error = %v", err)
+	}
+
+	var other dotenv.Env
+	if err := other.UnmarshalYAML(data); err != nil {
+		t.Fatalf("UnmarshalYAML() error = %v", err)
+	}
+	if got := other.Get("a"); got != "1" {
+		t.Errorf("Get(a) = %q, want %q", got, "1")
+	}
+	if got := other.Get("b"); got != "has: colon" {
+		t.Errorf("Get(b) = %q, want %q", got, "has: colon")
+	}
+}
+
+func TestEnv_Diff(t *testing.T) {
+	a := &dotenv.Env{}
+	if err := a.LoadReader(strings.NewReader("x=\"1\"\ny=\"2\"\n")); err != nil {
+		t.Fatal(err)
+	}
+	b := &dotenv.Env{}
+	if err := b.LoadReader(strings.NewReader("y=\"3\"\nz=\"4\"\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	added, changed, removed := a.Diff(b)
+	if len(added) != 1 || added[0] != "z" {
+		t.Errorf("added = %v, want [z]", added)
+	}
+	if len(changed) != 1 || changed[0] != "y" {
+		t.Errorf("changed = %v, want [y]", changed)
+	}
+	if len(removed) != 1 || removed[0] != "x" {
+		t.Errorf("removed = %v, want [x]", removed)
+	}
+}
+
+func TestEnv_DiffConcurrentAccess(t *testing.T) {
+	a := &dotenv.Env{}
+	if err := a.LoadReader(strings.NewReader("x=\"1\"\n")); err != nil {
+		t.Fatal(err)
+	}
+	b := &dotenv.Env{}
+	if err := b.LoadReader(strings.NewReader("y=\"2\"\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			b.LoadReader(strings.NewReader("y=\"2\"\n"))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		a.Diff(b)
+	}
+	<-done
+}
+
+func TestEnv_MergeConcurrentAccess(t *testing.T) {
+	a := &dotenv.Env{}
+	if err := a.LoadReader(strings.NewReader("x=\"1\"\n")); err != nil {
+		t.Fatal(err)
+	}
+	b := &dotenv.Env{}
+	if err := b.LoadReader(strings.NewReader("y=\"2\"\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			b.LoadReader(strings.NewReader("y=\"2\"\n"))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		a.Merge(b, true)
+	}
+	<-done
+}